@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command machine-controller-openstack-grpc serves the OpenStack machine-controller as an out-of-tree MCM
+// gRPC driver, as opposed to the in-process driver registered directly with MCM's controller binary.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	mcmgrpc "github.com/gardener/machine-controller-manager-provider-openstack/pkg/grpc"
+
+	"google.golang.org/grpc"
+	"k8s.io/klog"
+)
+
+var (
+	grpcAddr       = flag.String("endpoint", ":8082", "address the driver gRPC service listens on")
+	healthAddr     = flag.String("health-addr", ":8083", "address the health/readiness endpoint listens on")
+	credentialsDir = flag.String("credentials-dir", "/var/run/secrets/openstack", "directory a cloud-credentials Secret is mounted at")
+	certFile       = flag.String("tls-cert-file", "", "server certificate for the gRPC endpoint; TLS is disabled if unset")
+	keyFile        = flag.String("tls-key-file", "", "server private key for the gRPC endpoint")
+	clientCAFile   = flag.String("tls-client-ca-file", "", "CA used to verify client certificates; enables mTLS if set")
+)
+
+func main() {
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	if err := run(); err != nil {
+		klog.Errorf("machine-controller-openstack-grpc exited with error: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var opts []grpc.ServerOption
+	if *certFile != "" {
+		tlsOption, err := (mcmgrpc.TLSOptions{CertFile: *certFile, KeyFile: *keyFile, CAFile: *clientCAFile}).ServerOption()
+		if err != nil {
+			return fmt.Errorf("failed to configure TLS: %w", err)
+		}
+		opts = append(opts, tlsOption)
+	} else {
+		klog.Warningf("starting gRPC driver endpoint without TLS; set --tls-cert-file/--tls-key-file for production use")
+	}
+
+	listener, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", *grpcAddr, err)
+	}
+
+	driver := mcmgrpc.NewDriver(*credentialsDir)
+	server := grpc.NewServer(opts...)
+	mcmgrpc.RegisterMachineDriverServer(server, driver)
+
+	go func() {
+		if err := mcmgrpc.ServeHealthz(*healthAddr); err != nil {
+			klog.Errorf("health endpoint stopped: %v", err)
+		}
+	}()
+
+	klog.Infof("serving MCM driver gRPC endpoint on %s", *grpcAddr)
+	return server.Serve(listener)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package openstack holds the provider-specific MachineClass configuration for the OpenStack provider.
+package openstack
+
+// MachineProviderConfig is the provider-specific configuration carried by a MachineClass's providerSpec for
+// the OpenStack provider.
+type MachineProviderConfig struct {
+	Spec MachineProviderConfigSpec `json:"spec"`
+}
+
+// MachineProviderConfigSpec describes the desired shape of a machine backed by an OpenStack Nova server.
+type MachineProviderConfigSpec struct {
+	// Region is the OpenStack region the machine is created in.
+	Region string `json:"region"`
+	// AvailabilityZone is the Nova availability zone the machine is scheduled into.
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+	// ImageName resolves to an ImageID at create time; ignored if ImageID is set.
+	ImageName string `json:"imageName,omitempty"`
+	// ImageID pins the boot image by ID, bypassing ImageName resolution.
+	ImageID string `json:"imageID,omitempty"`
+	// FlavorName is the Nova flavor the machine is created with.
+	FlavorName string `json:"flavorName"`
+	// KeyName is the Nova keypair injected into the machine.
+	KeyName string `json:"keyName,omitempty"`
+	// SecurityGroups lists the Neutron security groups (by name) attached to the machine's ports.
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+	// Tags are written as Nova server metadata, and carry the cluster/role identifiers used to find
+	// machines belonging to this MachineClass.
+	Tags map[string]string `json:"tags,omitempty"`
+	// ServerGroupID, if set, schedules the machine as a member of the given Nova server group.
+	ServerGroupID *string `json:"serverGroupID,omitempty"`
+	// UseConfigDrive forces cloud-init metadata to be delivered via a config drive instead of the metadata
+	// service.
+	UseConfigDrive *bool `json:"useConfigDrive,omitempty"`
+	// RootDiskSize, if non-zero, boots the machine from a Cinder volume of this size (GiB) instead of
+	// ephemeral storage. Superseded by a non-empty BlockDevices.
+	RootDiskSize int `json:"rootDiskSize,omitempty"`
+	// BlockDevices lists the block_device_mapping_v2 entries attached to the machine at boot. A non-empty
+	// list takes priority over RootDiskSize.
+	BlockDevices []BlockDeviceSpec `json:"blockDevices,omitempty"`
+	// NetworkID, if set, attaches the machine directly to this network, bypassing Networks.
+	NetworkID string `json:"networkID,omitempty"`
+	// SubnetID, used together with NetworkID, pre-allocates a Neutron port on this subnet.
+	SubnetID *string `json:"subnetID,omitempty"`
+	// Networks lists the networks the machine is attached to when NetworkID is not set.
+	Networks []NetworkSpec `json:"networks,omitempty"`
+	// PodNetworkCidr is whitelisted as an allowed-address-pair on every port flagged as pod-carrying.
+	PodNetworkCidr string `json:"podNetworkCidr"`
+	// FloatingIP, if set, allocates (or reuses) a floating IP from the given pool and associates it with
+	// the machine's primary pod-network port.
+	FloatingIP *FloatingIPSpec `json:"floatingIP,omitempty"`
+}
+
+// NetworkSpec identifies a network the machine is attached to.
+type NetworkSpec struct {
+	// Id is the Neutron network ID; resolved from Name if empty.
+	Id string `json:"id,omitempty"`
+	// Name is the Neutron network name, used to resolve Id when it is not set.
+	Name string `json:"name,omitempty"`
+	// PodNetwork marks this network's port as carrying pod traffic: PodNetworkCidr is whitelisted on it,
+	// and (together with Primary) it is eligible for floating IP association.
+	PodNetwork bool `json:"podNetwork,omitempty"`
+	// Primary marks this as the network whose port a configured FloatingIP is associated with, when more
+	// than one network is flagged PodNetwork.
+	Primary bool `json:"primary,omitempty"`
+	// PortID, if set, attaches this existing Neutron port instead of creating a new one.
+	PortID string `json:"portID,omitempty"`
+	// PortType selects the Nova vif-plugging mechanism for a pre-created port, e.g. "normal" (the default)
+	// or "direct" for SR-IOV passthrough.
+	PortType string `json:"portType,omitempty"`
+	// VNICType sets the Neutron port-binding vnic_type (e.g. "normal", "direct", "macvtap"), requiring the
+	// port to be pre-created via the portsbinding extension rather than handed to Nova as a bare network ID.
+	VNICType string `json:"vnicType,omitempty"`
+	// BindingProfile carries provider-specific port-binding hints (e.g. "physical_network") through to the
+	// Neutron port-binding extension, alongside VNICType.
+	BindingProfile map[string]string `json:"bindingProfile,omitempty"`
+	// Trunk, if set, wraps this network's port in a Neutron trunk carrying the declared sub-ports, so that
+	// tagged secondary NICs can be demultiplexed by the guest.
+	Trunk *TrunkSpec `json:"trunk,omitempty"`
+}
+
+// TrunkSpec declares the sub-ports of a Neutron trunk wrapping the port of the network entry it is set on.
+type TrunkSpec struct {
+	// SubPorts lists the trunk's sub-ports, each carrying a secondary NIC tagged into the guest.
+	SubPorts []SubPortSpec `json:"subPorts,omitempty"`
+}
+
+// SubPortSpec is a single sub-port of a TrunkSpec.
+type SubPortSpec struct {
+	// NetworkID is the Neutron network the sub-port is created on.
+	NetworkID string `json:"networkID"`
+	// SegmentationType is the trunk segmentation mechanism, e.g. "vlan".
+	SegmentationType string `json:"segmentationType"`
+	// SegmentationID is the segmentation tag (e.g. VLAN ID) the guest sees this sub-port's traffic on.
+	SegmentationID int `json:"segmentationID"`
+}
+
+// FloatingIPSpec selects the Neutron floating-IP pool a machine's floating IP is allocated from.
+type FloatingIPSpec struct {
+	// FloatingNetworkID is the ID of the external network to allocate the floating IP from; resolved from
+	// FloatingNetworkName when empty.
+	FloatingNetworkID string `json:"floatingNetworkID,omitempty"`
+	// FloatingNetworkName is the name of the external network to allocate the floating IP from.
+	FloatingNetworkName string `json:"floatingNetworkName,omitempty"`
+	// Address pre-allocates a specific floating IP address instead of letting Neutron pick one.
+	Address string `json:"address,omitempty"`
+}
+
+// CinderVolumeSource identifies a Cinder volume backing a Kubernetes PersistentVolume, so the driver can
+// resolve it to the volume ID MCM waits on during node drain/delete.
+type CinderVolumeSource struct {
+	// VolumeID is the Cinder volume ID.
+	VolumeID string `json:"volumeID"`
+}
+
+// BlockDeviceSpec is a single block_device_mapping_v2 entry attached to the machine at boot.
+type BlockDeviceSpec struct {
+	// SourceType is one of "image", "volume", "snapshot", "blank".
+	SourceType string `json:"sourceType"`
+	// DestinationType is one of "volume", "local".
+	DestinationType string `json:"destinationType"`
+	// UUID is the source image/volume/snapshot ID. Left empty for a "blank" source, or to mean "the
+	// machine's boot image" when SourceType is "image".
+	UUID string `json:"uuid,omitempty"`
+	// VolumeType selects the Cinder volume type backing this device, when DestinationType is "volume".
+	VolumeType string `json:"volumeType,omitempty"`
+	// VolumeSize is the size, in GiB, of the Cinder volume backing this device.
+	VolumeSize int `json:"volumeSize,omitempty"`
+	// BootIndex orders this device among the machine's block devices; 0 is the boot device.
+	BootIndex int `json:"bootIndex"`
+	// DeleteOnTermination controls whether Nova deletes the backing volume when the machine is deleted.
+	// Volumes with DeleteOnTermination=false are detached, not deleted, by DeleteMachine.
+	DeleteOnTermination bool `json:"deleteOnTermination"`
+}
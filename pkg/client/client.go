@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client wraps the gophercloud OpenStack SDK behind the narrow Compute/Network interfaces the
+// executor package depends on, so the executor can be tested against fakes and stay agnostic of gophercloud
+// plumbing (auth, endpoints, pagination).
+package client
+
+import (
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+// Nova server status strings used by Executor.waitForStatus.
+const (
+	ServerStatusBuild      = "BUILD"
+	ServerStatusActive     = "ACTIVE"
+	ServerStatusError      = "ERROR"
+	ServerStatusDeleted    = "DELETED"
+	ServerStatusShutoff    = "SHUTOFF"
+	ServerStatusReboot     = "REBOOT"
+	ServerStatusHardReboot = "HARD_REBOOT"
+)
+
+// Compute is the subset of the Nova API Executor depends on.
+type Compute interface {
+	CreateServer(opts servers.CreateOptsBuilder) (*servers.Server, error)
+	BootFromVolume(opts servers.CreateOptsBuilder) (*servers.Server, error)
+	GetServer(id string) (*servers.Server, error)
+	DeleteServer(id string) error
+	ListServers(opts servers.ListOptsBuilder) ([]servers.Server, error)
+	ImageIDFromName(name string) (string, error)
+	FlavorIDFromName(name string) (string, error)
+
+	ListVolumeAttachments(serverID string) ([]volumeattach.Attachment, error)
+	DetachVolume(serverID, volumeID string) error
+
+	Stop(serverID string) error
+	Start(serverID string) error
+	Reboot(serverID string, rebootType servers.RebootMethod) error
+
+	// SupportsServerTags reports whether this endpoint's negotiated Nova microversion supports the
+	// server-tags API (2.52+), letting callers choose server-side tag filtering over the metadata-based
+	// fallback.
+	SupportsServerTags() bool
+	// TagServer adds a single tag to a server via the server-tags API. Only valid when SupportsServerTags
+	// returns true.
+	TagServer(serverID, tag string) error
+	// ListServersByTags lists servers with opts.Tags applied server-side, sending the negotiated
+	// server-tags microversion on the request itself so Nova actually honors the filter instead of silently
+	// ignoring it (pre-2.52) or 404ing (some releases). Only valid when SupportsServerTags returns true.
+	ListServersByTags(opts servers.ListOptsBuilder) ([]servers.Server, error)
+}
+
+// Network is the subset of the Neutron API Executor depends on.
+type Network interface {
+	GetSubnet(id string) (*subnets.Subnet, error)
+	NetworkIDFromName(name string) (string, error)
+	GroupIDFromName(name string) (string, error)
+
+	CreatePort(opts *ports.CreateOpts) (*ports.Port, error)
+	// CreatePortOpts creates a port from a builder, so callers that need to layer extensions (e.g.
+	// portsbinding) onto the base ports.CreateOpts aren't limited to CreatePort's concrete type.
+	CreatePortOpts(opts ports.CreateOptsBuilder) (*ports.Port, error)
+	// GetPort returns a single port by ID, e.g. to resolve a trunk sub-port that is never itself attached
+	// to a server's device_id (only its trunk's parent port is).
+	GetPort(id string) (*ports.Port, error)
+	UpdatePort(id string, opts ports.UpdateOptsBuilder) error
+	ListPorts(opts ports.ListOptsBuilder) ([]ports.Port, error)
+	PortIDFromName(name string) (string, error)
+	DeletePort(id string) error
+
+	// CreateTrunk wraps a parent port in a Neutron trunk carrying the given sub-ports.
+	CreateTrunk(opts trunks.CreateOpts) (*trunks.Trunk, error)
+	// GetTrunkByPortID returns the trunk whose parent port is id, or nil if the port is not a trunk parent.
+	GetTrunkByPortID(portID string) (*trunks.Trunk, error)
+	// DeleteTrunk deletes the trunk (but not its sub-ports, which callers must delete separately).
+	DeleteTrunk(id string) error
+
+	CreateFloatingIP(opts floatingips.CreateOptsBuilder) (*floatingips.FloatingIP, error)
+	ListFloatingIPs(opts floatingips.ListOptsBuilder) ([]floatingips.FloatingIP, error)
+	UpdateFloatingIP(id string, opts floatingips.UpdateOptsBuilder) error
+	DeleteFloatingIP(id string) error
+
+	// TagResource attaches a Neutron "standard-attr-tag" tag to a resource (e.g. "floatingips", "ports")
+	// identified by id.
+	TagResource(resourceType, id, tag string) error
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client/microversion"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/tags"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+)
+
+// computeClient is the gophercloud-backed implementation of Compute.
+type computeClient struct {
+	serviceClient *gophercloud.ServiceClient
+	microversions *microversion.Negotiator
+}
+
+func newComputeClient(serviceClient *gophercloud.ServiceClient, negotiator *microversion.Negotiator) *computeClient {
+	return &computeClient{serviceClient: serviceClient, microversions: negotiator}
+}
+
+func (c *computeClient) CreateServer(opts servers.CreateOptsBuilder) (*servers.Server, error) {
+	return servers.Create(c.serviceClient, opts).Extract()
+}
+
+func (c *computeClient) BootFromVolume(opts servers.CreateOptsBuilder) (*servers.Server, error) {
+	return servers.Create(c.serviceClient, opts).Extract()
+}
+
+func (c *computeClient) GetServer(id string) (*servers.Server, error) {
+	return servers.Get(c.serviceClient, id).Extract()
+}
+
+func (c *computeClient) DeleteServer(id string) error {
+	return servers.Delete(c.serviceClient, id).ExtractErr()
+}
+
+func (c *computeClient) ListServers(opts servers.ListOptsBuilder) ([]servers.Server, error) {
+	pages, err := servers.List(c.serviceClient, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return servers.ExtractServers(pages)
+}
+
+func (c *computeClient) ImageIDFromName(name string) (string, error) {
+	return images.IDFromName(c.serviceClient, name)
+}
+
+func (c *computeClient) FlavorIDFromName(name string) (string, error) {
+	return flavors.IDFromName(c.serviceClient, name)
+}
+
+func (c *computeClient) ListVolumeAttachments(serverID string) ([]volumeattach.Attachment, error) {
+	pages, err := volumeattach.List(c.serviceClient, serverID).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return volumeattach.ExtractVolumeAttachments(pages)
+}
+
+func (c *computeClient) DetachVolume(serverID, volumeID string) error {
+	return volumeattach.Delete(c.serviceClient, serverID, volumeID).ExtractErr()
+}
+
+func (c *computeClient) Stop(serverID string) error {
+	return startstop.Stop(c.serviceClient, serverID).ExtractErr()
+}
+
+func (c *computeClient) Start(serverID string) error {
+	return startstop.Start(c.serviceClient, serverID).ExtractErr()
+}
+
+func (c *computeClient) Reboot(serverID string, rebootType servers.RebootMethod) error {
+	return servers.Reboot(c.serviceClient, serverID, servers.RebootOpts{Type: rebootType}).ExtractErr()
+}
+
+func (c *computeClient) SupportsServerTags() bool {
+	return c.microversions.Supports(c.serviceClient, microversion.ServerTagsMicroversion)
+}
+
+func (c *computeClient) TagServer(serverID, tag string) error {
+	return tags.Add(c.withMicroversion(microversion.ServerTagsMicroversion), serverID, tag).ExtractErr()
+}
+
+func (c *computeClient) ListServersByTags(opts servers.ListOptsBuilder) ([]servers.Server, error) {
+	pages, err := servers.List(c.withMicroversion(microversion.ServerTagsMicroversion), opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return servers.ExtractServers(pages)
+}
+
+// withMicroversion returns a shallow copy of the service client with its Microversion field set to mv, so
+// the X-OpenStack-Nova-API-Version header is sent on this one request without mutating c.serviceClient (and
+// racing any concurrent call using the unversioned client).
+func (c *computeClient) withMicroversion(mv string) *gophercloud.ServiceClient {
+	versioned := *c.serviceClient
+	versioned.Microversion = mv
+	return &versioned
+}
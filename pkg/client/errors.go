@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// notFoundError is returned by the network client's name-resolution helpers (NetworkIDFromName,
+// GroupIDFromName, PortIDFromName) when a lookup does not resolve to exactly one match, so callers can test
+// for it the same way they test gophercloud's own ErrDefault404 via IsNotFoundError.
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string { return e.msg }
+
+func newNotFoundErrorf(format string, args ...interface{}) error {
+	return &notFoundError{msg: fmt.Sprintf(format, args...)}
+}
+
+// IsNotFoundError reports whether err represents a "resource does not exist" condition, whether that is a
+// 404 returned by gophercloud or a resolution helper that found zero or more-than-one match.
+func IsNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*notFoundError); ok {
+		return true
+	}
+	if _, ok := err.(gophercloud.ErrDefault404); ok {
+		return true
+	}
+	return false
+}
+
+// IsTransientError reports whether err is a server-side or rate-limit error gophercloud surfaces as a 5xx or
+// 429 response, so callers like waitForStatus can retry instead of failing the whole operation on a blip.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch err.(type) {
+	case gophercloud.ErrDefault429,
+		gophercloud.ErrDefault500,
+		gophercloud.ErrDefault502,
+		gophercloud.ErrDefault503,
+		gophercloud.ErrDefault504:
+		return true
+	default:
+		return false
+	}
+}
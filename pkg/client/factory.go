@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client/microversion"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+)
+
+// Factory builds region-scoped Compute/Network clients off a single authenticated provider client.
+type Factory struct {
+	providerClient *gophercloud.ProviderClient
+	microversions  *microversion.Negotiator
+}
+
+// Option customizes a client built by Factory.
+type Option func(*gophercloud.EndpointOpts)
+
+// WithRegion scopes the built client's endpoint lookup to region.
+func WithRegion(region string) Option {
+	return func(opts *gophercloud.EndpointOpts) {
+		opts.Region = region
+	}
+}
+
+func applyOptions(opts ...Option) gophercloud.EndpointOpts {
+	var endpointOpts gophercloud.EndpointOpts
+	for _, opt := range opts {
+		opt(&endpointOpts)
+	}
+	return endpointOpts
+}
+
+// NewFactory authenticates against the cloud described by authOpts and returns a Factory that can build
+// Compute/Network clients scoped to it.
+func NewFactory(authOpts gophercloud.AuthOptions) (*Factory, error) {
+	providerClient, err := openstack.AuthenticatedClient(authOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
+	}
+	return &Factory{providerClient: providerClient, microversions: microversion.New()}, nil
+}
+
+// Compute returns a Compute client scoped by opts.
+func (f *Factory) Compute(opts ...Option) (Compute, error) {
+	serviceClient, err := openstack.NewComputeV2(f.providerClient, applyOptions(opts...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute client: %w", err)
+	}
+	return newComputeClient(serviceClient, f.microversions), nil
+}
+
+// Network returns a Network client scoped by opts.
+func (f *Factory) Network(opts ...Option) (Network, error) {
+	serviceClient, err := openstack.NewNetworkV2(f.providerClient, applyOptions(opts...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network client: %w", err)
+	}
+	return newNetworkClient(serviceClient), nil
+}
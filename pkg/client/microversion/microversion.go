@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package microversion negotiates the Nova compute API microversion a given endpoint supports, so that
+// callers can opt into newer API behaviour (such as server-side tag filtering, added in 2.52) while
+// transparently falling back to the base API on older clouds.
+package microversion
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gophercloud/gophercloud"
+	"k8s.io/klog"
+)
+
+// ServerTagsMicroversion is the lowest Nova microversion that supports the "tags" server attribute and
+// server-side ListOpts.Tags filtering.
+const ServerTagsMicroversion = "2.52"
+
+// headerName is the Nova-specific microversion request header.
+const headerName = "X-OpenStack-Nova-API-Version"
+
+// Negotiator caches the negotiated microversion per compute endpoint, so repeated calls against the same
+// cloud do not re-probe on every request.
+type Negotiator struct {
+	mu    sync.RWMutex
+	cache map[string]bool
+}
+
+// New returns an empty Negotiator.
+func New() *Negotiator {
+	return &Negotiator{cache: make(map[string]bool)}
+}
+
+// Supports reports whether the compute endpoint behind client supports microversion, probing and caching
+// the result on first use. Probing sets the microversion request header and issues a cheap GET against
+// /servers/detail with a Limit of 1; a 406 Not Acceptable means the cloud rejected the requested
+// microversion and we fall back to the base API, any other successful response means it is supported.
+func (n *Negotiator) Supports(client *gophercloud.ServiceClient, microversion string) bool {
+	key := client.Endpoint + "|" + microversion
+
+	n.mu.RLock()
+	supported, known := n.cache[key]
+	n.mu.RUnlock()
+	if known {
+		return supported
+	}
+
+	supported = n.probe(client, microversion)
+
+	n.mu.Lock()
+	n.cache[key] = supported
+	n.mu.Unlock()
+
+	return supported
+}
+
+func (n *Negotiator) probe(client *gophercloud.ServiceClient, microversion string) bool {
+	url := client.ServiceURL("servers", "detail") + "?limit=1"
+	_, err := client.Request(http.MethodGet, url, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{headerName: microversion},
+		OkCodes:     []int{http.StatusOK},
+	})
+	if err != nil {
+		if response, ok := err.(gophercloud.ErrUnexpectedResponseCode); ok && response.Actual == http.StatusNotAcceptable {
+			klog.V(3).Infof("compute endpoint %q does not support microversion %q, falling back", client.Endpoint, microversion)
+			return false
+		}
+		klog.V(3).Infof("failed to probe microversion %q against %q: %v, assuming unsupported", microversion, client.Endpoint, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+// networkClient is the gophercloud-backed implementation of Network.
+type networkClient struct {
+	serviceClient *gophercloud.ServiceClient
+}
+
+func newNetworkClient(serviceClient *gophercloud.ServiceClient) *networkClient {
+	return &networkClient{serviceClient: serviceClient}
+}
+
+func (n *networkClient) GetSubnet(id string) (*subnets.Subnet, error) {
+	return subnets.Get(n.serviceClient, id).Extract()
+}
+
+func (n *networkClient) NetworkIDFromName(name string) (string, error) {
+	pages, err := networks.List(n.serviceClient, networks.ListOpts{Name: name}).AllPages()
+	if err != nil {
+		return "", err
+	}
+	matches, err := networks.ExtractNetworks(pages)
+	if err != nil {
+		return "", err
+	}
+	if len(matches) != 1 {
+		return "", newNotFoundErrorf("network [Name=%q]: found %d matches", name, len(matches))
+	}
+	return matches[0].ID, nil
+}
+
+func (n *networkClient) GroupIDFromName(name string) (string, error) {
+	pages, err := groups.List(n.serviceClient, groups.ListOpts{Name: name}).AllPages()
+	if err != nil {
+		return "", err
+	}
+	matches, err := groups.ExtractGroups(pages)
+	if err != nil {
+		return "", err
+	}
+	if len(matches) != 1 {
+		return "", newNotFoundErrorf("security group [Name=%q]: found %d matches", name, len(matches))
+	}
+	return matches[0].ID, nil
+}
+
+func (n *networkClient) CreatePort(opts *ports.CreateOpts) (*ports.Port, error) {
+	return ports.Create(n.serviceClient, opts).Extract()
+}
+
+func (n *networkClient) CreatePortOpts(opts ports.CreateOptsBuilder) (*ports.Port, error) {
+	return ports.Create(n.serviceClient, opts).Extract()
+}
+
+func (n *networkClient) GetPort(id string) (*ports.Port, error) {
+	return ports.Get(n.serviceClient, id).Extract()
+}
+
+func (n *networkClient) UpdatePort(id string, opts ports.UpdateOptsBuilder) error {
+	_, err := ports.Update(n.serviceClient, id, opts).Extract()
+	return err
+}
+
+func (n *networkClient) ListPorts(opts ports.ListOptsBuilder) ([]ports.Port, error) {
+	pages, err := ports.List(n.serviceClient, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ports.ExtractPorts(pages)
+}
+
+func (n *networkClient) PortIDFromName(name string) (string, error) {
+	matches, err := n.ListPorts(ports.ListOpts{Name: name})
+	if err != nil {
+		return "", err
+	}
+	if len(matches) != 1 {
+		return "", newNotFoundErrorf("port [Name=%q]: found %d matches", name, len(matches))
+	}
+	return matches[0].ID, nil
+}
+
+func (n *networkClient) DeletePort(id string) error {
+	return ports.Delete(n.serviceClient, id).ExtractErr()
+}
+
+func (n *networkClient) CreateFloatingIP(opts floatingips.CreateOptsBuilder) (*floatingips.FloatingIP, error) {
+	return floatingips.Create(n.serviceClient, opts).Extract()
+}
+
+func (n *networkClient) ListFloatingIPs(opts floatingips.ListOptsBuilder) ([]floatingips.FloatingIP, error) {
+	pages, err := floatingips.List(n.serviceClient, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return floatingips.ExtractFloatingIPs(pages)
+}
+
+func (n *networkClient) UpdateFloatingIP(id string, opts floatingips.UpdateOptsBuilder) error {
+	_, err := floatingips.Update(n.serviceClient, id, opts).Extract()
+	return err
+}
+
+func (n *networkClient) DeleteFloatingIP(id string) error {
+	return floatingips.Delete(n.serviceClient, id).ExtractErr()
+}
+
+func (n *networkClient) TagResource(resourceType, id, tag string) error {
+	return attributestags.Add(n.serviceClient, resourceType, id, tag).ExtractErr()
+}
+
+func (n *networkClient) CreateTrunk(opts trunks.CreateOpts) (*trunks.Trunk, error) {
+	return trunks.Create(n.serviceClient, opts).Extract()
+}
+
+func (n *networkClient) GetTrunkByPortID(portID string) (*trunks.Trunk, error) {
+	pages, err := trunks.List(n.serviceClient, trunks.ListOpts{PortID: portID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	matches, err := trunks.ExtractTrunks(pages)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return &matches[0], nil
+}
+
+func (n *networkClient) DeleteTrunk(id string) error {
+	return trunks.Delete(n.serviceClient, id).ExtractErr()
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// secretKey names the fields NewFactoryFromSecret/NewFactoryFromCredentialsDir read the cloud credentials
+// off of, mirroring the cloud-config keys the in-tree provider has always expected in the MachineClass's
+// referenced Secret.
+const (
+	secretKeyAuthURL    = "authURL"
+	secretKeyUsername   = "username"
+	secretKeyPassword   = "password"
+	secretKeyDomainName = "domainName"
+	secretKeyTenantName = "tenantName"
+)
+
+// NewFactoryFromSecret builds a Factory from the cloud credentials carried in secret, the same credential
+// shape the in-process driver.Driver has always read out of a MachineClass's Secret reference.
+func NewFactoryFromSecret(secret *cloudprovider.Secret) (*Factory, error) {
+	return newFactoryFromData(secret.Data)
+}
+
+// NewFactoryFromCredentialsDir builds a Factory from the cloud credentials mounted at dir, one file per
+// secretKey (the same layout a Kubernetes Secret volume mount produces). This is the out-of-tree gRPC
+// driver's credential path: it materializes a Factory from its own pod's mounted Secret instead of the
+// Secret an RPC caller passes in-band, decoupling the driver's process from MCM's.
+func NewFactoryFromCredentialsDir(dir string) (*Factory, error) {
+	data := make(map[string][]byte)
+	for _, key := range []string{secretKeyAuthURL, secretKeyUsername, secretKeyPassword, secretKeyDomainName, secretKeyTenantName} {
+		content, err := os.ReadFile(filepath.Join(dir, key))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read credential file %q: %w", filepath.Join(dir, key), err)
+		}
+		data[key] = content
+	}
+	return newFactoryFromData(data)
+}
+
+func newFactoryFromData(data map[string][]byte) (*Factory, error) {
+	authURL, err := requireDataKey(data, secretKeyAuthURL)
+	if err != nil {
+		return nil, err
+	}
+	username, err := requireDataKey(data, secretKeyUsername)
+	if err != nil {
+		return nil, err
+	}
+	password, err := requireDataKey(data, secretKeyPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint: authURL,
+		Username:         username,
+		Password:         password,
+		DomainName:       string(data[secretKeyDomainName]),
+		TenantName:       string(data[secretKeyTenantName]),
+	}
+
+	return NewFactory(authOpts)
+}
+
+func requireDataKey(data map[string][]byte, key string) (string, error) {
+	value, ok := data[key]
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("credentials are missing required key %q", key)
+	}
+	return string(value), nil
+}
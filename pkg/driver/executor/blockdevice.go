@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"fmt"
+	"sort"
+
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/bootfromvolume"
+	"k8s.io/klog"
+)
+
+// resourceInstanceBlockDevicesFromSpec translates a user-supplied BlockDevices list into the Nova
+// block_device_mapping_v2 entries expected by the bootfromvolume extension. A SourceType of "image" with no
+// explicit UUID is resolved to the machine's boot image, mirroring the single-volume convenience path in
+// resourceInstanceBlockDevicesV2.
+func resourceInstanceBlockDevicesFromSpec(blockDevices []api.BlockDeviceSpec, imageID string) ([]bootfromvolume.BlockDevice, error) {
+	blockDeviceOpts := make([]bootfromvolume.BlockDevice, 0, len(blockDevices))
+	for _, bd := range blockDevices {
+		uuid := bd.UUID
+		if uuid == "" && bd.SourceType == "image" {
+			uuid = imageID
+		}
+
+		blockDeviceOpts = append(blockDeviceOpts, bootfromvolume.BlockDevice{
+			UUID:                uuid,
+			SourceType:          bootfromvolume.SourceType(bd.SourceType),
+			DestinationType:     bootfromvolume.DestinationType(bd.DestinationType),
+			VolumeType:          bd.VolumeType,
+			VolumeSize:          bd.VolumeSize,
+			BootIndex:           bd.BootIndex,
+			DeleteOnTermination: bd.DeleteOnTermination,
+		})
+	}
+
+	klog.V(3).Infof("[DEBUG] Block Device Options: %+v", blockDeviceOpts)
+	return blockDeviceOpts, nil
+}
+
+// blockDeviceKeepOnDelete returns the set of BootIndex values that must NOT be deleted when the server is
+// torn down, because their corresponding BlockDevices entry has DeleteOnTermination=false. It is keyed by
+// BootIndex rather than the spec's UUID: for a "volume" source, UUID is the real, pre-existing Cinder volume
+// ID, but for "image"/"blank"/"snapshot" sources Nova creates a brand-new volume at boot whose ID is never
+// equal to UUID (which is empty, or an image/snapshot ID). BootIndex is the only part of the spec that still
+// identifies that volume after creation.
+func blockDeviceKeepOnDelete(blockDevices []api.BlockDeviceSpec) map[int]bool {
+	keep := make(map[int]bool)
+	for _, bd := range blockDevices {
+		if !bd.DeleteOnTermination {
+			keep[bd.BootIndex] = true
+		}
+	}
+	return keep
+}
+
+// detachPreservedVolumes detaches (without deleting) any volume attachment on the server whose backing
+// BlockDevices entry requested DeleteOnTermination=false, so that Nova's server-delete cascade does not
+// reclaim volumes the user asked to keep. Since the Nova API has no direct "attachment for boot index N"
+// lookup, attachments are recovered by device name instead: Nova assigns device paths (/dev/vda, /dev/vdb,
+// ...) in the same order as BootIndex, so sorting attachments by Device and indexing them 0, 1, 2, ... maps
+// each one back to the BlockDevices entry it was created from.
+func (ex *Executor) detachPreservedVolumes(serverID string, blockDevices []api.BlockDeviceSpec) error {
+	keep := blockDeviceKeepOnDelete(blockDevices)
+	if len(keep) == 0 {
+		return nil
+	}
+
+	attachments, err := ex.Compute.ListVolumeAttachments(serverID)
+	if err != nil {
+		return fmt.Errorf("failed to list volume attachments for server [ID=%q]: %w", serverID, err)
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].Device < attachments[j].Device })
+
+	for bootIndex, attachment := range attachments {
+		if !keep[bootIndex] {
+			continue
+		}
+
+		klog.V(3).Infof("detaching preserved volume [ID=%q] (boot index %d) from server [ID=%q] before delete", attachment.VolumeID, bootIndex, serverID)
+		if err := ex.Compute.DetachVolume(serverID, attachment.VolumeID); err != nil {
+			return fmt.Errorf("failed to detach preserved volume [ID=%q] from server [ID=%q]: %w", attachment.VolumeID, serverID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"testing"
+
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+)
+
+// TestDetachPreservedVolumes_MatchesByBootIndexNotSpecUUID guards against regressing back to keying the
+// "preserve on delete" set by the BlockDevices entry's UUID: for an image-sourced boot disk, UUID is empty
+// (or an image ID), never the Cinder volume ID Nova allocates for it at boot, so matching on it can never
+// find the real attachment. The real volume must still be detached, recovered via its device-path order.
+func TestDetachPreservedVolumes_MatchesByBootIndexNotSpecUUID(t *testing.T) {
+	blockDevices := []api.BlockDeviceSpec{
+		{SourceType: "image", DestinationType: "volume", VolumeType: "ssd", BootIndex: 0, DeleteOnTermination: false},
+		{SourceType: "blank", DestinationType: "volume", BootIndex: 1, DeleteOnTermination: true},
+	}
+
+	var detached []string
+	ex := &Executor{
+		Compute: &fakeCompute{
+			t: t,
+			listVolumeAttachmentsFn: func(serverID string) ([]volumeattach.Attachment, error) {
+				return []volumeattach.Attachment{
+					{ServerID: serverID, VolumeID: "vol-data", Device: "/dev/vdb"},
+					{ServerID: serverID, VolumeID: "vol-root", Device: "/dev/vda"},
+				}, nil
+			},
+			detachVolumeFn: func(_, volumeID string) error {
+				detached = append(detached, volumeID)
+				return nil
+			},
+		},
+	}
+
+	if err := ex.detachPreservedVolumes("server-1", blockDevices); err != nil {
+		t.Fatalf("detachPreservedVolumes: %v", err)
+	}
+
+	if len(detached) != 1 || detached[0] != "vol-root" {
+		t.Fatalf("expected only the boot-index-0 volume [vol-root] to be detached, got %v", detached)
+	}
+}
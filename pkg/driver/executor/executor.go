@@ -8,12 +8,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"math"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
-	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
 	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
 
@@ -80,14 +79,30 @@ func (ex *Executor) CreateMachine(ctx context.Context, machineName string, userD
 		return err
 	}
 
-	err = ex.waitForStatus(server.ID, []string{client.ServerStatusBuild}, []string{client.ServerStatusActive}, 600)
+	err = ex.waitForStatus(ctx, server.ID, []string{client.ServerStatusBuild}, []string{client.ServerStatusActive}, 600*time.Second)
 	if err != nil {
 		return "", deleteOnFail(fmt.Errorf("error waiting for server [ID=%q] to reach target status: %w", server.ID, err))
 	}
 
-	if err := ex.patchServerPortsForPodNetwork(server.ID); err != nil {
+	if err := ex.tagServerWithClusterRole(server.ID); err != nil {
+		return "", deleteOnFail(fmt.Errorf("failed to tag server [ID=%q]: %w", server.ID, err))
+	}
+
+	podNetworkPorts, err := ex.patchServerPortsForPodNetwork(server.ID)
+	if err != nil {
 		return "", deleteOnFail(fmt.Errorf("failed to patch server [ID=%q] ports: %s", server.ID, err))
 	}
+
+	if ex.Config.Spec.FloatingIP != nil {
+		primaryPort, err := ex.primaryPodNetworkPort(podNetworkPorts)
+		if err != nil {
+			return "", deleteOnFail(fmt.Errorf("failed to determine primary port for floating IP on server [ID=%q]: %w", server.ID, err))
+		}
+		if err := ex.attachFloatingIP(machineName, primaryPort.ID); err != nil {
+			return "", deleteOnFail(fmt.Errorf("failed to attach floating IP to server [ID=%q]: %w", server.ID, err))
+		}
+	}
+
 	return providerID, nil
 }
 
@@ -152,7 +167,14 @@ func (ex *Executor) computeServerNetworks(machineName string) ([]servers.Network
 		} else {
 			resolvedNetworkID = network.Id
 		}
-		serverNetworks = append(serverNetworks, servers.Network{UUID: resolvedNetworkID})
+
+		// PortType/VNICType/BindingProfile/Trunk all require a pre-created Neutron port, e.g. for SR-IOV
+		// (direct) NICs or trunk parent ports; a plain network attachment lets Nova create the port itself.
+		portID, err := ex.resolveNetworkPort(machineName, resolvedNetworkID, network)
+		if err != nil {
+			return nil, err
+		}
+		serverNetworks = append(serverNetworks, servers.Network{UUID: resolvedNetworkID, Port: portID})
 	}
 
 	return serverNetworks, nil
@@ -202,7 +224,32 @@ func (ex *Executor) computePodNetworkIDs(serverID string) ([]ports.Port, error)
 		return nil, fmt.Errorf("got an empty port list for server %q", serverID)
 	}
 
+	// A trunk's sub-ports carry secondary/SR-IOV NICs but are never themselves attached to the server (only
+	// the trunk's parent port has device_id=serverID), so they never show up in serverPorts above. Resolve
+	// them explicitly for every server port that turns out to be a trunk parent, so a sub-port on a network
+	// flagged PodNetwork is still a candidate below.
+	candidatePorts := serverPorts
 	for _, port := range serverPorts {
+		trunk, err := ex.Network.GetTrunkByPortID(port.ID)
+		if err != nil {
+			if client.IsNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to look up trunk for port [ID=%q]: %w", port.ID, err)
+		}
+		if trunk == nil {
+			continue
+		}
+		for _, subPort := range trunk.Subports {
+			subPortDetails, err := ex.Network.GetPort(subPort.PortID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get trunk sub-port [ID=%q]: %w", subPort.PortID, err)
+			}
+			candidatePorts = append(candidatePorts, *subPortDetails)
+		}
+	}
+
+	for _, port := range candidatePorts {
 		if networkIDs.Has(port.NetworkID) {
 			result = append(result, port)
 		}
@@ -215,15 +262,36 @@ func (ex *Executor) computePodNetworkIDs(serverID string) ([]ports.Port, error)
 	return result, nil
 }
 
+// waitForStatusBackoff is the exponential backoff used by waitForStatus: an initial 1s interval growing by a
+// factor of 1.5 up to a 15s cap, with 20% jitter to avoid synchronized retries against the same cloud.
+var waitForStatusBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   1.5,
+	Jitter:   0.2,
+	Cap:      15 * time.Second,
+	Steps:    math.MaxInt32,
+}
+
 // waitForStatus blocks until the server with the specified ID reaches one of the target status.
-// waitForStatus will fail if an error occurs, the operation it timeouts after the specified time, or the server status is not in the pending list.
-func (ex *Executor) waitForStatus(serverID string, pending []string, target []string, secs int) error {
-	return wait.Poll(time.Second, time.Duration(secs)*time.Second, func() (done bool, err error) {
+// waitForStatus will fail if an error occurs, the operation timeouts, or the server reaches a status outside
+// the pending list. ctx bounds the overall wait in addition to timeout, so a caller's reconcile deadline
+// (e.g. a gRPC deadline in the out-of-tree driver) actually aborts the poll. Transient errors from the
+// compute API (5xx, 429) do not abort the wait; they are retried with the same backoff.
+func (ex *Executor) waitForStatus(ctx context.Context, serverID string, pending []string, target []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := waitForStatusBackoff
+	condition := func(ctx context.Context) (done bool, err error) {
 		current, err := ex.Compute.GetServer(serverID)
 		if err != nil {
 			if client.IsNotFoundError(err) && strSliceContains(target, client.ServerStatusDeleted) {
 				return true, nil
 			}
+			if client.IsTransientError(err) {
+				klog.V(4).Infof("transient error waiting for server [ID=%q], retrying: %v", serverID, err)
+				return false, nil
+			}
 			return false, err
 		}
 
@@ -243,7 +311,20 @@ func (ex *Executor) waitForStatus(serverID string, pending []string, target []st
 		}
 
 		return false, retErr
-	})
+	}
+
+	// Poll by hand rather than via wait.ExponentialBackoffWithContext: reaching Cap zeroes the backoff's
+	// Steps, which would end that loop after ~47s; Step keeps returning the (jittered) cap instead.
+	for {
+		if done, err := condition(ctx); err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff.Step()):
+		}
+	}
 }
 
 // deployServer handles creating the server instance.
@@ -305,6 +386,21 @@ func (ex *Executor) deployServer(machineName string, userData []byte, nws []serv
 		}
 	}
 
+	// A non-empty BlockDevices list always takes priority over the legacy single-volume RootDiskSize field,
+	// since it is a strict superset (a single root volume is just a one-entry BlockDevices list).
+	if len(ex.Config.Spec.BlockDevices) > 0 {
+		blockDevices, err := resourceInstanceBlockDevicesFromSpec(ex.Config.Spec.BlockDevices, imageRef)
+		if err != nil {
+			return nil, err
+		}
+
+		createOpts = &bootfromvolume.CreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			BlockDevice:       blockDevices,
+		}
+		return ex.Compute.BootFromVolume(createOpts)
+	}
+
 	// If a custom block_device (root disk size is provided) we need to boot from volume
 	if rootDiskSize > 0 {
 		blockDevices, err := resourceInstanceBlockDevicesV2(rootDiskSize, imageRef)
@@ -336,21 +432,22 @@ func resourceInstanceBlockDevicesV2(rootDiskSize int, imageID string) ([]bootfro
 	return blockDeviceOpts, nil
 }
 
-// patchServerPortsForPodNetwork updates a server's ports with rules for whitelisting the pod network CIDR.
-func (ex *Executor) patchServerPortsForPodNetwork(serverID string) error {
+// patchServerPortsForPodNetwork updates a server's ports with rules for whitelisting the pod network CIDR,
+// and returns the patched ports so callers can act on the pod-carrying ports (e.g. floating IP association).
+func (ex *Executor) patchServerPortsForPodNetwork(serverID string) ([]ports.Port, error) {
 	podNetworkPorts, err := ex.computePodNetworkIDs(serverID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, port := range podNetworkPorts {
 		if err := ex.Network.UpdatePort(port.ID, ports.UpdateOpts{
 			AllowedAddressPairs: &[]ports.AddressPair{{IPAddress: ex.Config.Spec.PodNetworkCidr}},
 		}); err != nil {
-			return fmt.Errorf("failed to update allowed address pair for port [ID=%q]: %v", port.ID, err)
+			return nil, fmt.Errorf("failed to update allowed address pair for port [ID=%q]: %v", port.ID, err)
 		}
 	}
-	return nil
+	return podNetworkPorts, nil
 }
 
 // DeleteMachine deletes a server based on the supplied ID or name. The machine must have the cluster/role tags for any operation to take place.
@@ -374,15 +471,33 @@ func (ex *Executor) DeleteMachine(ctx context.Context, machineName, providerID s
 		return err
 	}
 
+	if len(ex.Config.Spec.BlockDevices) > 0 {
+		if err := ex.detachPreservedVolumes(server.ID, ex.Config.Spec.BlockDevices); err != nil {
+			return err
+		}
+	}
+
+	if err := ex.deleteTrunksForServer(server.ID); err != nil {
+		return err
+	}
+
 	klog.V(1).Infof("deleting server [ID=%s]", server.ID)
 	if err := ex.Compute.DeleteServer(server.ID); err != nil {
 		return err
 	}
 
-	if err = ex.waitForStatus(server.ID, nil, []string{client.ServerStatusDeleted}, 300); err != nil {
+	if err = ex.waitForStatus(ctx, server.ID, nil, []string{client.ServerStatusDeleted}, 300*time.Second); err != nil {
 		return fmt.Errorf("error while waiting for server [ID=%q] to be deleted: %v", server.ID, err)
 	}
 
+	if ex.Config.Spec.FloatingIP != nil {
+		if err := ex.releaseFloatingIP(machineName); err != nil {
+			// best-effort: a leaked floating IP is cheaper to clean up by hand than a failed delete that
+			// keeps the machine object around and blocks the next reconcile.
+			klog.Errorf("failed to release floating IP for machine %q: %v", machineName, err)
+		}
+	}
+
 	if !isEmptyString(ex.Config.Spec.SubnetID) {
 		return ex.deletePort(ctx, machineName)
 	}
@@ -429,17 +544,7 @@ func (ex *Executor) getMachineByProviderID(_ context.Context, providerID string)
 		return nil, err
 	}
 
-	var (
-		searchClusterName string
-		searchNodeRole    string
-	)
-	for key := range ex.Config.Spec.Tags {
-		if strings.Contains(key, cloudprovider.ServerTagClusterPrefix) {
-			searchClusterName = key
-		} else if strings.Contains(key, cloudprovider.ServerTagRolePrefix) {
-			searchNodeRole = key
-		}
-	}
+	searchClusterName, searchNodeRole := clusterRoleTags(ex.Config.Spec.Tags)
 
 	if _, nameOk := server.Metadata[searchClusterName]; nameOk {
 		if _, roleOk := server.Metadata[searchNodeRole]; roleOk {
@@ -454,46 +559,20 @@ func (ex *Executor) getMachineByProviderID(_ context.Context, providerID string)
 // getMachineByName returns a server that matches the following criteria:
 // a) has the same name as machineName
 // b) has the cluster and role tags as set in the machineClass
-// The current approach is weak because the tags are currently stored as server metadata. Later Nova versions allow
-// to store tags in a respective field and do a server-side filtering. To avoid incompatibility with older versions
-// we will continue making the filtering clientside.
+// Filtering prefers the server-side tag filter on Nova microversion 2.52+ (see listServersByClusterRole) and
+// falls back to matching the tags against server metadata client-side on older clouds.
 func (ex *Executor) getMachineByName(_ context.Context, machineName string) (*servers.Server, error) {
-	var (
-		searchClusterName string
-		searchNodeRole    string
-	)
-
-	for key := range ex.Config.Spec.Tags {
-		if strings.Contains(key, cloudprovider.ServerTagClusterPrefix) {
-			searchClusterName = key
-		} else if strings.Contains(key, cloudprovider.ServerTagRolePrefix) {
-			searchNodeRole = key
-		}
-	}
-
+	searchClusterName, searchNodeRole := clusterRoleTags(ex.Config.Spec.Tags)
 	if searchClusterName == "" || searchNodeRole == "" {
 		klog.Warningf("getMachineByName operation can not proceed: cluster/role tags are missing for machine [Name=%q]", machineName)
 		return nil, fmt.Errorf("getMachineByName operation can not proceed: cluster/role tags are missing for machine [Name=%q]", machineName)
 	}
 
-	listedServers, err := ex.Compute.ListServers(&servers.ListOpts{
-		Name: machineName,
-	})
+	matchingServers, err := ex.listServersByClusterRole(servers.ListOpts{Name: machineName}, searchClusterName, searchNodeRole)
 	if err != nil {
 		return nil, err
 	}
 
-	matchingServers := []servers.Server{}
-	for _, server := range listedServers {
-		if server.Name == machineName {
-			if _, nameOk := server.Metadata[searchClusterName]; nameOk {
-				if _, roleOk := server.Metadata[searchNodeRole]; roleOk {
-					matchingServers = append(matchingServers, server)
-				}
-			}
-		}
-	}
-
 	if len(matchingServers) > 1 {
 		return nil, fmt.Errorf("failed to find server [Name=%q]: %w", machineName, ErrMultipleFound)
 	} else if len(matchingServers) == 0 {
@@ -536,38 +615,23 @@ outerLoop:
 	return nil
 }
 
-// ListMachines lists all servers.
+// ListMachines lists all servers belonging to this MachineClass's cluster/role.
 func (ex *Executor) ListMachines(_ context.Context) (map[string]string, error) {
-	searchClusterName := ""
-	searchNodeRole := ""
-
-	for key := range ex.Config.Spec.Tags {
-		if strings.Contains(key, cloudprovider.ServerTagClusterPrefix) {
-			searchClusterName = key
-		} else if strings.Contains(key, cloudprovider.ServerTagRolePrefix) {
-			searchNodeRole = key
-		}
-	}
-
-	//
+	searchClusterName, searchNodeRole := clusterRoleTags(ex.Config.Spec.Tags)
 	if searchClusterName == "" || searchNodeRole == "" {
 		klog.Warningf("operation can not proceed: cluster/role tags are missing")
 		return nil, fmt.Errorf("operation can not proceed: cluster/role tags are missing")
 	}
 
-	servers, err := ex.Compute.ListServers(&servers.ListOpts{})
+	matchingServers, err := ex.listServersByClusterRole(servers.ListOpts{}, searchClusterName, searchNodeRole)
 	if err != nil {
 		return nil, err
 	}
 
 	result := map[string]string{}
-	for _, server := range servers {
-		if _, nameOk := server.Metadata[searchClusterName]; nameOk {
-			if _, roleOk := server.Metadata[searchNodeRole]; roleOk {
-				providerID := EncodeProviderID(ex.Config.Spec.Region, server.ID)
-				result[providerID] = server.Name
-			}
-		}
+	for _, server := range matchingServers {
+		providerID := EncodeProviderID(ex.Config.Spec.Region, server.ID)
+		result[providerID] = server.Name
 	}
 
 	return result, nil
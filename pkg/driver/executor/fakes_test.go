@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+// fakeCompute is a client.Compute test double: every method is backed by an overridable function field, and
+// calls a test did not configure fail it loudly instead of panicking or silently no-op-ing.
+type fakeCompute struct {
+	t *testing.T
+
+	listServersByTagsFn     func(opts servers.ListOptsBuilder) ([]servers.Server, error)
+	listServersFn           func(opts servers.ListOptsBuilder) ([]servers.Server, error)
+	supportsServerTagsFn    func() bool
+	listVolumeAttachmentsFn func(serverID string) ([]volumeattach.Attachment, error)
+	detachVolumeFn          func(serverID, volumeID string) error
+}
+
+func (f *fakeCompute) unexpected(method string) {
+	f.t.Helper()
+	f.t.Fatalf("fakeCompute.%s: not configured for this test", method)
+}
+
+func (f *fakeCompute) CreateServer(servers.CreateOptsBuilder) (*servers.Server, error) {
+	f.unexpected("CreateServer")
+	return nil, nil
+}
+func (f *fakeCompute) BootFromVolume(servers.CreateOptsBuilder) (*servers.Server, error) {
+	f.unexpected("BootFromVolume")
+	return nil, nil
+}
+func (f *fakeCompute) GetServer(string) (*servers.Server, error) {
+	f.unexpected("GetServer")
+	return nil, nil
+}
+func (f *fakeCompute) DeleteServer(string) error {
+	f.unexpected("DeleteServer")
+	return nil
+}
+func (f *fakeCompute) ListServers(opts servers.ListOptsBuilder) ([]servers.Server, error) {
+	if f.listServersFn == nil {
+		f.unexpected("ListServers")
+		return nil, nil
+	}
+	return f.listServersFn(opts)
+}
+func (f *fakeCompute) ImageIDFromName(string) (string, error) {
+	f.unexpected("ImageIDFromName")
+	return "", nil
+}
+func (f *fakeCompute) FlavorIDFromName(string) (string, error) {
+	f.unexpected("FlavorIDFromName")
+	return "", nil
+}
+func (f *fakeCompute) ListVolumeAttachments(serverID string) ([]volumeattach.Attachment, error) {
+	if f.listVolumeAttachmentsFn == nil {
+		f.unexpected("ListVolumeAttachments")
+		return nil, nil
+	}
+	return f.listVolumeAttachmentsFn(serverID)
+}
+func (f *fakeCompute) DetachVolume(serverID, volumeID string) error {
+	if f.detachVolumeFn == nil {
+		f.unexpected("DetachVolume")
+		return nil
+	}
+	return f.detachVolumeFn(serverID, volumeID)
+}
+func (f *fakeCompute) Stop(string) error {
+	f.unexpected("Stop")
+	return nil
+}
+func (f *fakeCompute) Start(string) error {
+	f.unexpected("Start")
+	return nil
+}
+func (f *fakeCompute) Reboot(string, servers.RebootMethod) error {
+	f.unexpected("Reboot")
+	return nil
+}
+func (f *fakeCompute) SupportsServerTags() bool {
+	if f.supportsServerTagsFn == nil {
+		f.unexpected("SupportsServerTags")
+		return false
+	}
+	return f.supportsServerTagsFn()
+}
+func (f *fakeCompute) TagServer(string, string) error {
+	f.unexpected("TagServer")
+	return nil
+}
+func (f *fakeCompute) ListServersByTags(opts servers.ListOptsBuilder) ([]servers.Server, error) {
+	if f.listServersByTagsFn == nil {
+		f.unexpected("ListServersByTags")
+		return nil, nil
+	}
+	return f.listServersByTagsFn(opts)
+}
+
+var _ client.Compute = &fakeCompute{}
+
+// fakeNetwork is a client.Network test double, following the same overridable-function-field pattern as
+// fakeCompute.
+type fakeNetwork struct {
+	t *testing.T
+
+	networkIDFromNameFn func(name string) (string, error)
+}
+
+func (f *fakeNetwork) unexpected(method string) {
+	f.t.Helper()
+	f.t.Fatalf("fakeNetwork.%s: not configured for this test", method)
+}
+
+func (f *fakeNetwork) GetSubnet(string) (*subnets.Subnet, error) {
+	f.unexpected("GetSubnet")
+	return nil, nil
+}
+func (f *fakeNetwork) NetworkIDFromName(name string) (string, error) {
+	if f.networkIDFromNameFn == nil {
+		f.unexpected("NetworkIDFromName")
+		return "", nil
+	}
+	return f.networkIDFromNameFn(name)
+}
+func (f *fakeNetwork) GroupIDFromName(string) (string, error) {
+	f.unexpected("GroupIDFromName")
+	return "", nil
+}
+func (f *fakeNetwork) CreatePort(*ports.CreateOpts) (*ports.Port, error) {
+	f.unexpected("CreatePort")
+	return nil, nil
+}
+func (f *fakeNetwork) CreatePortOpts(ports.CreateOptsBuilder) (*ports.Port, error) {
+	f.unexpected("CreatePortOpts")
+	return nil, nil
+}
+func (f *fakeNetwork) GetPort(string) (*ports.Port, error) {
+	f.unexpected("GetPort")
+	return nil, nil
+}
+func (f *fakeNetwork) UpdatePort(string, ports.UpdateOptsBuilder) error {
+	f.unexpected("UpdatePort")
+	return nil
+}
+func (f *fakeNetwork) ListPorts(ports.ListOptsBuilder) ([]ports.Port, error) {
+	f.unexpected("ListPorts")
+	return nil, nil
+}
+func (f *fakeNetwork) PortIDFromName(string) (string, error) {
+	f.unexpected("PortIDFromName")
+	return "", nil
+}
+func (f *fakeNetwork) DeletePort(string) error {
+	f.unexpected("DeletePort")
+	return nil
+}
+func (f *fakeNetwork) CreateTrunk(trunks.CreateOpts) (*trunks.Trunk, error) {
+	f.unexpected("CreateTrunk")
+	return nil, nil
+}
+func (f *fakeNetwork) GetTrunkByPortID(string) (*trunks.Trunk, error) {
+	f.unexpected("GetTrunkByPortID")
+	return nil, nil
+}
+func (f *fakeNetwork) DeleteTrunk(string) error {
+	f.unexpected("DeleteTrunk")
+	return nil
+}
+func (f *fakeNetwork) CreateFloatingIP(floatingips.CreateOptsBuilder) (*floatingips.FloatingIP, error) {
+	f.unexpected("CreateFloatingIP")
+	return nil, nil
+}
+func (f *fakeNetwork) ListFloatingIPs(floatingips.ListOptsBuilder) ([]floatingips.FloatingIP, error) {
+	f.unexpected("ListFloatingIPs")
+	return nil, nil
+}
+func (f *fakeNetwork) UpdateFloatingIP(string, floatingips.UpdateOptsBuilder) error {
+	f.unexpected("UpdateFloatingIP")
+	return nil
+}
+func (f *fakeNetwork) DeleteFloatingIP(string) error {
+	f.unexpected("DeleteFloatingIP")
+	return nil
+}
+func (f *fakeNetwork) TagResource(string, string, string) error {
+	f.unexpected("TagResource")
+	return nil
+}
+
+var _ client.Network = &fakeNetwork{}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"fmt"
+
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"k8s.io/klog"
+	"k8s.io/utils/pointer"
+)
+
+// primaryPodNetworkPort picks the single port a configured FloatingIP is associated with out of the
+// server's pod-network ports. With exactly one pod-network port the choice is unambiguous; with more than
+// one (e.g. once secondary/trunk NICs also carry pod traffic) the network must be marked Primary in
+// Spec.Networks, since picking an arbitrary port would attach the floating IP to the wrong interface.
+func (ex *Executor) primaryPodNetworkPort(podNetworkPorts []ports.Port) (*ports.Port, error) {
+	if len(podNetworkPorts) == 0 {
+		return nil, fmt.Errorf("no pod-network ports found")
+	}
+	if len(podNetworkPorts) == 1 {
+		return &podNetworkPorts[0], nil
+	}
+
+	for _, network := range ex.Config.Spec.Networks {
+		if !network.Primary {
+			continue
+		}
+
+		resolvedNetworkID := network.Id
+		if isEmptyString(pointer.StringPtr(resolvedNetworkID)) {
+			var err error
+			resolvedNetworkID, err = ex.Network.NetworkIDFromName(network.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for i := range podNetworkPorts {
+			if podNetworkPorts[i].NetworkID == resolvedNetworkID {
+				return &podNetworkPorts[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("server has %d pod-network ports but none of its networks is marked Primary", len(podNetworkPorts))
+}
+
+// floatingIPTag returns the Neutron tag that the controller attaches to any floating IP it allocates for a
+// machine, so that a re-created machine can find (and reuse) an IP it already owns instead of leaking it.
+func floatingIPTag(machineName string) string {
+	return fmt.Sprintf("mcm:%s", machineName)
+}
+
+// attachFloatingIP allocates (or reuses) a floating IP from the pool configured in Spec.FloatingIP and
+// associates it with the given port. It is a no-op if no FloatingIP pool is configured.
+func (ex *Executor) attachFloatingIP(machineName, portID string) error {
+	floatingIPSpec := ex.Config.Spec.FloatingIP
+	if floatingIPSpec == nil {
+		return nil
+	}
+
+	fip, err := ex.findReusableFloatingIP(machineName)
+	if err != nil {
+		return err
+	}
+
+	if fip == nil {
+		fip, err = ex.createFloatingIP(machineName, floatingIPSpec)
+		if err != nil {
+			return fmt.Errorf("failed to allocate floating IP for machine %q: %w", machineName, err)
+		}
+	}
+
+	if fip.PortID == portID {
+		return nil
+	}
+
+	klog.V(3).Infof("associating floating IP [ID=%q, addr=%q] with port [ID=%q]", fip.ID, fip.FloatingIP, portID)
+	if err := ex.Network.UpdateFloatingIP(fip.ID, floatingips.UpdateOpts{PortID: &portID}); err != nil {
+		return fmt.Errorf("failed to associate floating IP [ID=%q] with port [ID=%q]: %w", fip.ID, portID, err)
+	}
+
+	return nil
+}
+
+// findReusableFloatingIP looks for a floating IP that was previously allocated by the controller for this
+// machine (identified by the mcm:<machineName> tag) so that a re-created machine does not leak IPs.
+func (ex *Executor) findReusableFloatingIP(machineName string) (*floatingips.FloatingIP, error) {
+	existing, err := ex.Network.ListFloatingIPs(floatingips.ListOpts{Tags: floatingIPTag(machineName)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list floating IPs for machine %q: %w", machineName, err)
+	}
+
+	if len(existing) == 0 {
+		return nil, nil
+	}
+
+	klog.V(3).Infof("reusing floating IP [ID=%q, addr=%q] for machine %q", existing[0].ID, existing[0].FloatingIP, machineName)
+	return &existing[0], nil
+}
+
+func (ex *Executor) createFloatingIP(machineName string, floatingIPSpec *api.FloatingIPSpec) (*floatingips.FloatingIP, error) {
+	floatingNetworkID := floatingIPSpec.FloatingNetworkID
+	if isEmptyString(pointer.StringPtr(floatingNetworkID)) {
+		var err error
+		floatingNetworkID, err = ex.Network.NetworkIDFromName(floatingIPSpec.FloatingNetworkName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve floating IP pool %q: %w", floatingIPSpec.FloatingNetworkName, err)
+		}
+	}
+
+	fip, err := ex.Network.CreateFloatingIP(floatingips.CreateOpts{
+		FloatingNetworkID: floatingNetworkID,
+		FloatingIP:        floatingIPSpec.Address,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ex.Network.TagResource("floatingips", fip.ID, floatingIPTag(machineName)); err != nil {
+		klog.Errorf("failed to tag floating IP [ID=%q] for machine %q: %v", fip.ID, machineName, err)
+	}
+
+	klog.V(3).Infof("allocated floating IP [ID=%q, addr=%q] for machine %q", fip.ID, fip.FloatingIP, machineName)
+	return fip, nil
+}
+
+// releaseFloatingIP disassociates and deletes any floating IP tagged as owned by the given machine.
+// It is best-effort: callers (including deleteOnFail cleanup) only log a failure here, they do not fail the
+// overall delete operation because of it.
+func (ex *Executor) releaseFloatingIP(machineName string) error {
+	floatingIPSpec := ex.Config.Spec.FloatingIP
+	if floatingIPSpec == nil {
+		return nil
+	}
+
+	owned, err := ex.Network.ListFloatingIPs(floatingips.ListOpts{Tags: floatingIPTag(machineName)})
+	if err != nil {
+		return fmt.Errorf("failed to list floating IPs for machine %q: %w", machineName, err)
+	}
+
+	for _, fip := range owned {
+		klog.V(3).Infof("releasing floating IP [ID=%q, addr=%q] owned by machine %q", fip.ID, fip.FloatingIP, machineName)
+		if err := ex.Network.DeleteFloatingIP(fip.ID); err != nil {
+			if client.IsNotFoundError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to release floating IP [ID=%q]: %w", fip.ID, err)
+		}
+	}
+
+	return nil
+}
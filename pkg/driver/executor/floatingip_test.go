@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"testing"
+
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+// TestPrimaryPodNetworkPort_MultiplePorts guards against regressing back to blindly picking
+// podNetworkPorts[0]: with more than one pod-network port, the port on the network marked Primary must win,
+// not whichever port the Neutron list happened to return first.
+func TestPrimaryPodNetworkPort_MultiplePorts(t *testing.T) {
+	ex := &Executor{
+		Config: &api.MachineProviderConfig{
+			Spec: api.MachineProviderConfigSpec{
+				Networks: []api.NetworkSpec{
+					{Id: "net-a", PodNetwork: true},
+					{Id: "net-b", PodNetwork: true, Primary: true},
+				},
+			},
+		},
+	}
+
+	podNetworkPorts := []ports.Port{
+		{ID: "port-a", NetworkID: "net-a"},
+		{ID: "port-b", NetworkID: "net-b"},
+	}
+
+	got, err := ex.primaryPodNetworkPort(podNetworkPorts)
+	if err != nil {
+		t.Fatalf("primaryPodNetworkPort: %v", err)
+	}
+	if got.ID != "port-b" {
+		t.Fatalf("expected the Primary network's port [ID=%q], got [ID=%q]", "port-b", got.ID)
+	}
+}
+
+// TestPrimaryPodNetworkPort_SinglePort covers the unambiguous single-port shortcut.
+func TestPrimaryPodNetworkPort_SinglePort(t *testing.T) {
+	ex := &Executor{Config: &api.MachineProviderConfig{}}
+
+	got, err := ex.primaryPodNetworkPort([]ports.Port{{ID: "port-a", NetworkID: "net-a"}})
+	if err != nil {
+		t.Fatalf("primaryPodNetworkPort: %v", err)
+	}
+	if got.ID != "port-a" {
+		t.Fatalf("expected [ID=%q], got [ID=%q]", "port-a", got.ID)
+	}
+}
+
+// TestPrimaryPodNetworkPort_NoPrimaryMarked asserts that more than one pod-network port without a Primary
+// network is a hard error, rather than silently falling back to an arbitrary port.
+func TestPrimaryPodNetworkPort_NoPrimaryMarked(t *testing.T) {
+	ex := &Executor{
+		Config: &api.MachineProviderConfig{
+			Spec: api.MachineProviderConfigSpec{
+				Networks: []api.NetworkSpec{
+					{Id: "net-a", PodNetwork: true},
+					{Id: "net-b", PodNetwork: true},
+				},
+			},
+		},
+	}
+
+	podNetworkPorts := []ports.Port{
+		{ID: "port-a", NetworkID: "net-a"},
+		{ID: "port-b", NetworkID: "net-b"},
+	}
+
+	if _, err := ex.primaryPodNetworkPort(podNetworkPorts); err == nil {
+		t.Fatal("expected an error when no network is marked Primary")
+	}
+}
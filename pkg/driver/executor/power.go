@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"k8s.io/klog"
+	"k8s.io/utils/pointer"
+)
+
+// stopStartTimeout bounds how long StopMachine/StartMachine wait for the server to settle into its
+// target status. Power transitions are expected to be much faster than a full build, so the timeout is a lot
+// tighter than the 600s CreateMachine allows.
+const stopStartTimeout = 300 * time.Second
+
+// StopMachine requests a graceful shutdown of the server (ACPI shutdown via the startstop extension) and
+// waits for it to reach SHUTOFF.
+func (ex *Executor) StopMachine(ctx context.Context, machineName, providerID string) error {
+	server, err := ex.resolveServerForPowerOp(ctx, machineName, providerID)
+	if err != nil {
+		return err
+	}
+
+	klog.V(2).Infof("stopping server [ID=%q]", server.ID)
+	if err := ex.Compute.Stop(server.ID); err != nil {
+		return fmt.Errorf("failed to stop server [ID=%q]: %w", server.ID, err)
+	}
+
+	if err := ex.waitForStatus(ctx, server.ID, []string{client.ServerStatusActive}, []string{client.ServerStatusShutoff}, stopStartTimeout); err != nil {
+		return fmt.Errorf("error waiting for server [ID=%q] to stop: %w", server.ID, err)
+	}
+	return nil
+}
+
+// StartMachine powers a previously stopped server back on and waits for it to reach ACTIVE.
+func (ex *Executor) StartMachine(ctx context.Context, machineName, providerID string) error {
+	server, err := ex.resolveServerForPowerOp(ctx, machineName, providerID)
+	if err != nil {
+		return err
+	}
+
+	klog.V(2).Infof("starting server [ID=%q]", server.ID)
+	if err := ex.Compute.Start(server.ID); err != nil {
+		return fmt.Errorf("failed to start server [ID=%q]: %w", server.ID, err)
+	}
+
+	if err := ex.waitForStatus(ctx, server.ID, []string{client.ServerStatusShutoff}, []string{client.ServerStatusActive}, stopStartTimeout); err != nil {
+		return fmt.Errorf("error waiting for server [ID=%q] to start: %w", server.ID, err)
+	}
+	return nil
+}
+
+// RebootMachine reboots the server, either a "soft" (graceful OS shutdown and restart) or "hard" (power
+// cycle) reboot, and waits for it to return to ACTIVE.
+func (ex *Executor) RebootMachine(ctx context.Context, machineName, providerID string, hard bool) error {
+	server, err := ex.resolveServerForPowerOp(ctx, machineName, providerID)
+	if err != nil {
+		return err
+	}
+
+	rebootType := servers.SoftReboot
+	if hard {
+		rebootType = servers.HardReboot
+	}
+
+	klog.V(2).Infof("rebooting server [ID=%q] (type=%s)", server.ID, rebootType)
+	if err := ex.Compute.Reboot(server.ID, rebootType); err != nil {
+		return fmt.Errorf("failed to reboot server [ID=%q]: %w", server.ID, err)
+	}
+
+	if err := ex.waitForStatus(ctx, server.ID, []string{client.ServerStatusActive, client.ServerStatusReboot, client.ServerStatusHardReboot}, []string{client.ServerStatusActive}, stopStartTimeout); err != nil {
+		return fmt.Errorf("error waiting for server [ID=%q] to come back up after reboot: %w", server.ID, err)
+	}
+	return nil
+}
+
+// resolveServerForPowerOp resolves the target server the same way DeleteMachine does: providerID takes
+// priority, falling back to a name+tag lookup.
+func (ex *Executor) resolveServerForPowerOp(ctx context.Context, machineName, providerID string) (*servers.Server, error) {
+	if isEmptyString(pointer.StringPtr(providerID)) {
+		return ex.getMachineByName(ctx, machineName)
+	}
+	return ex.getMachineByProviderID(ctx, providerID)
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"k8s.io/klog"
+)
+
+// clusterRoleTags returns the cluster/role keys that identify machines belonging to this MachineClass, in
+// the same order getMachineByName/ListMachines have always searched for them.
+func clusterRoleTags(tags map[string]string) (clusterTag, roleTag string) {
+	for key := range tags {
+		if strings.Contains(key, cloudprovider.ServerTagClusterPrefix) {
+			clusterTag = key
+		} else if strings.Contains(key, cloudprovider.ServerTagRolePrefix) {
+			roleTag = key
+		}
+	}
+	return clusterTag, roleTag
+}
+
+// tagServerWithClusterRole writes the cluster/role tags onto the Nova server resource itself via the
+// server-tags microversion (2.52+), so that list/get operations can filter server-side instead of pulling
+// every server's metadata over the wire. It is a no-op on clouds that do not support server tags; those
+// machines remain discoverable via the metadata-based fallback in getMachineByName/ListMachines.
+func (ex *Executor) tagServerWithClusterRole(serverID string) error {
+	if !ex.Compute.SupportsServerTags() {
+		return nil
+	}
+
+	clusterTag, roleTag := clusterRoleTags(ex.Config.Spec.Tags)
+	if clusterTag == "" || roleTag == "" {
+		return nil
+	}
+
+	for _, tag := range []string{clusterTag, roleTag} {
+		if err := ex.Compute.TagServer(serverID, tag); err != nil {
+			return fmt.Errorf("failed to tag server [ID=%q] with %q: %w", serverID, tag, err)
+		}
+	}
+	return nil
+}
+
+// listServersByClusterRole returns the servers belonging to this MachineClass's cluster/role, preferring the
+// server-side tag filter (Nova microversion 2.52+) and falling back to the client-side metadata filter that
+// getMachineByName/ListMachines have always used on older clouds. Nova's ListOpts.Name is a regex/substring
+// match in both cases, so whenever opts.Name is set we still re-check server.Name == opts.Name ourselves,
+// exactly like the original client-side-only getMachineByName did, so "worker-1" can't also match "worker-10".
+func (ex *Executor) listServersByClusterRole(opts servers.ListOpts, clusterTag, roleTag string) ([]servers.Server, error) {
+	var (
+		listed []servers.Server
+		err    error
+	)
+
+	if ex.Compute.SupportsServerTags() {
+		opts.Tags = strings.Join([]string{clusterTag, roleTag}, ",")
+		klog.V(3).Infof("listing servers with server-side tag filter %q", opts.Tags)
+		listed, err = ex.Compute.ListServersByTags(&opts)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		listed, err = ex.Compute.ListServers(&opts)
+		if err != nil {
+			return nil, err
+		}
+
+		filtered := make([]servers.Server, 0, len(listed))
+		for _, server := range listed {
+			if _, nameOk := server.Metadata[clusterTag]; nameOk {
+				if _, roleOk := server.Metadata[roleTag]; roleOk {
+					filtered = append(filtered, server)
+				}
+			}
+		}
+		listed = filtered
+	}
+
+	if opts.Name == "" {
+		return listed, nil
+	}
+
+	matching := make([]servers.Server, 0, len(listed))
+	for _, server := range listed {
+		if server.Name == opts.Name {
+			matching = append(matching, server)
+		}
+	}
+	return matching, nil
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+)
+
+// TestListServersByClusterRole_MetadataFallbackExactNameMatch guards against regressing back to Nova's
+// substring ListOpts.Name match: on the metadata-fallback path (no server-tags support), a lookup for
+// "worker-1" must not also return "worker-10", even though both carry matching cluster/role metadata.
+func TestListServersByClusterRole_MetadataFallbackExactNameMatch(t *testing.T) {
+	clusterTag, roleTag := "kubernetes.io-cluster-test", "kubernetes.io-role-worker"
+
+	ex := &Executor{
+		Compute: &fakeCompute{
+			t:                    t,
+			supportsServerTagsFn: func() bool { return false },
+			listServersFn: func(servers.ListOptsBuilder) ([]servers.Server, error) {
+				return []servers.Server{
+					{Name: "worker-1", Metadata: map[string]string{clusterTag: "1", roleTag: "1"}},
+					{Name: "worker-10", Metadata: map[string]string{clusterTag: "1", roleTag: "1"}},
+				}, nil
+			},
+		},
+	}
+
+	matches, err := ex.listServersByClusterRole(servers.ListOpts{Name: "worker-1"}, clusterTag, roleTag)
+	if err != nil {
+		t.Fatalf("listServersByClusterRole: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Name != "worker-1" {
+		t.Fatalf("expected exactly [worker-1], got %+v", matches)
+	}
+}
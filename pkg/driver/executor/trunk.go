@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executor
+
+import (
+	"fmt"
+
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/portsbinding"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/trunks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"k8s.io/klog"
+	"k8s.io/utils/pointer"
+)
+
+// resolveNetworkPort returns the Neutron port to attach for a network entry: its existing PortID as is, or a
+// port pre-created here when the entry asks for anything beyond the default binding (a non-"normal" PortType,
+// a VNICType, a BindingProfile, or a Trunk). It returns an empty string if the entry can be satisfied by handing Nova the bare network UUID, the
+// same as before this change.
+func (ex *Executor) resolveNetworkPort(machineName, networkID string, network api.NetworkSpec) (string, error) {
+	if !isEmptyString(pointer.StringPtr(network.PortID)) {
+		return network.PortID, nil
+	}
+
+	if !needsPrecreatedPort(network) {
+		return "", nil
+	}
+
+	var securityGroupIDs []string
+	for _, securityGroup := range ex.Config.Spec.SecurityGroups {
+		securityGroupID, err := ex.Network.GroupIDFromName(securityGroup)
+		if err != nil {
+			return "", err
+		}
+		securityGroupIDs = append(securityGroupIDs, securityGroupID)
+	}
+
+	createOpts := ports.CreateOptsBuilder(&ports.CreateOpts{
+		Name:           fmt.Sprintf("%s-%s", machineName, networkID),
+		NetworkID:      networkID,
+		SecurityGroups: &securityGroupIDs,
+	})
+
+	// A non-"normal" PortType is applied as the port's vnic_type unless VNICType is set explicitly.
+	vnicType := network.VNICType
+	if vnicType == "" && network.PortType != "" && network.PortType != "normal" {
+		vnicType = network.PortType
+	}
+	if vnicType != "" || network.BindingProfile != nil {
+		createOpts = portsbinding.CreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			VNICType:          vnicType,
+			Profile:           network.BindingProfile,
+		}
+	}
+
+	port, err := ex.Network.CreatePortOpts(createOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s port on network [ID=%q]: %w", defaultString(network.PortType, "normal"), networkID, err)
+	}
+	klog.V(3).Infof("pre-created %s port [ID=%q] on network [ID=%q]", defaultString(network.PortType, "normal"), port.ID, networkID)
+
+	if network.Trunk != nil {
+		if _, err := ex.createTrunk(machineName, port.ID, network.Trunk); err != nil {
+			return "", err
+		}
+	}
+
+	return port.ID, nil
+}
+
+func needsPrecreatedPort(network api.NetworkSpec) bool {
+	return (network.PortType != "" && network.PortType != "normal") ||
+		network.VNICType != "" ||
+		network.BindingProfile != nil ||
+		network.Trunk != nil
+}
+
+// createTrunk creates sub-ports for each of the trunk's declared members and wraps the given parent port in
+// a Neutron trunk resource, so that tagged/SR-IOV traffic can be demultiplexed by the guest.
+func (ex *Executor) createTrunk(machineName, parentPortID string, trunkSpec *api.TrunkSpec) (*trunks.Trunk, error) {
+	subPorts := make([]trunks.Subport, 0, len(trunkSpec.SubPorts))
+	for _, subPort := range trunkSpec.SubPorts {
+		port, err := ex.Network.CreatePortOpts(&ports.CreateOpts{
+			Name:      fmt.Sprintf("%s-%s-sub", machineName, subPort.NetworkID),
+			NetworkID: subPort.NetworkID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trunk sub-port on network [ID=%q]: %w", subPort.NetworkID, err)
+		}
+		subPorts = append(subPorts, trunks.Subport{
+			PortID:           port.ID,
+			SegmentationType: subPort.SegmentationType,
+			SegmentationID:   subPort.SegmentationID,
+		})
+	}
+
+	trunk, err := ex.Network.CreateTrunk(trunks.CreateOpts{
+		Name:     machineName,
+		PortID:   parentPortID,
+		Subports: subPorts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trunk for parent port [ID=%q]: %w", parentPortID, err)
+	}
+	klog.V(3).Infof("created trunk [ID=%q] for parent port [ID=%q] with %d sub-port(s)", trunk.ID, parentPortID, len(subPorts))
+	return trunk, nil
+}
+
+// deleteTrunksForServer tears down any trunk owned by the server's ports before the ports themselves (and
+// the server) are deleted, since Neutron refuses to delete a port that is still a trunk's parent.
+func (ex *Executor) deleteTrunksForServer(serverID string) error {
+	serverPorts, err := ex.Network.ListPorts(&ports.ListOpts{DeviceID: serverID})
+	if err != nil {
+		return fmt.Errorf("failed to list ports for server [ID=%q]: %w", serverID, err)
+	}
+
+	for _, port := range serverPorts {
+		trunk, err := ex.Network.GetTrunkByPortID(port.ID)
+		if err != nil {
+			if client.IsNotFoundError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to look up trunk for port [ID=%q]: %w", port.ID, err)
+		}
+		if trunk == nil {
+			continue
+		}
+
+		klog.V(3).Infof("deleting trunk [ID=%q] for port [ID=%q]", trunk.ID, port.ID)
+		if err := ex.Network.DeleteTrunk(trunk.ID); err != nil {
+			return fmt.Errorf("failed to delete trunk [ID=%q]: %w", trunk.ID, err)
+		}
+		for _, subPort := range trunk.Subports {
+			if err := ex.Network.DeletePort(subPort.PortID); err != nil {
+				klog.Errorf("failed to delete trunk sub-port [ID=%q]: %v", subPort.PortID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
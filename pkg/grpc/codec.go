@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the content-subtype jsonCodec registers under. It is deliberately NOT "proto" (the name
+// grpc-go's real protobuf codec claims and every generated stub defaults to): registering a JSON codec under
+// that name would hijack the process-wide codec registry and break any other protobuf traffic sharing this
+// process, and would silently fail every call from a real MCM control plane sending genuine protobuf bytes
+// instead of erroring loudly. A client must opt in with grpc.CallContentSubtype(codecName) to use it.
+//
+// messages.go's request/response structs are an interim wire schema, not the real out-of-tree MCM driver
+// proto (this environment has no protoc/vendored stubs to generate against). Swap this codec and the message
+// types in messages.go/service.go for the generated CMI stubs before pointing a real MCM control plane at
+// this server; until then it only talks to clients built against this package.
+const codecName = "json"
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"net"
+	"net/http"
+
+	"k8s.io/klog"
+)
+
+// ServeHealthz serves a trivial liveness/readiness endpoint on addr until the listener is closed, mirroring
+// the health servers MCM's other out-of-tree drivers expose for their readiness probes.
+func ServeHealthz(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("serving health checks on %s", addr)
+	return http.Serve(listener, mux)
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+)
+
+// The request/response pairs below are this service's wire messages. jsonCodec marshals them directly, so
+// there is no separate .proto-generated type to keep in sync with Driver's Go-facing method signatures.
+
+type createMachineRequest struct {
+	MachineClass *api.MachineProviderConfig
+	Secret       *cloudprovider.Secret
+	MachineName  string
+	UserData     []byte
+}
+
+type createMachineResponse struct {
+	ProviderID string
+}
+
+type deleteMachineRequest struct {
+	MachineClass *api.MachineProviderConfig
+	Secret       *cloudprovider.Secret
+	MachineName  string
+	ProviderID   string
+}
+
+type deleteMachineResponse struct{}
+
+type getMachineStatusRequest struct {
+	MachineClass *api.MachineProviderConfig
+	Secret       *cloudprovider.Secret
+	MachineName  string
+}
+
+type getMachineStatusResponse struct {
+	ProviderID string
+}
+
+type listMachinesRequest struct {
+	MachineClass *api.MachineProviderConfig
+	Secret       *cloudprovider.Secret
+}
+
+type listMachinesResponse struct {
+	MachineList map[string]string
+}
+
+type getVolumeIDsRequest struct {
+	VolumeSpecs []api.CinderVolumeSource
+}
+
+type getVolumeIDsResponse struct {
+	VolumeIDs []string
+}
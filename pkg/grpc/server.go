@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grpc serves the MCM out-of-tree driver gRPC service, delegating every call to an
+// executor.Executor built from the credentials materialized for the request. Unlike the in-process
+// cloudprovider-secret path used by the legacy in-tree driver, credentials here are read from a Secret
+// mounted into this binary's pod, decoupling the driver from MCM's process.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/cloudprovider"
+	api "github.com/gardener/machine-controller-manager-provider-openstack/pkg/apis/openstack"
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/client"
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/driver/executor"
+)
+
+// Driver implements the MCM driver gRPC service by delegating each call to an executor.Executor built from
+// the request's machine class and the credentials mounted at CredentialsDir; the request's secret is not used.
+type Driver struct {
+	// CredentialsDir is the path a Secret is mounted at (e.g. by a CSI-style driver-registrar sidecar),
+	// used to materialize cloud credentials for each request instead of an in-process Secret object.
+	CredentialsDir string
+}
+
+// NewDriver returns a gRPC driver that materializes credentials from the given mounted Secret directory.
+func NewDriver(credentialsDir string) *Driver {
+	return &Driver{CredentialsDir: credentialsDir}
+}
+
+// newExecutor builds an Executor from credentials materialized off d.CredentialsDir. The RPC's Secret
+// argument (see the Driver methods below) is deliberately not used for this: the out-of-tree driver
+// authenticates from its own pod's mounted Secret, not the caller's in-band one, so it stays decoupled from
+// MCM's process the same way any other out-of-tree CSI/CMI-style driver does.
+func (d *Driver) newExecutor(config *api.MachineProviderConfig) (*executor.Executor, error) {
+	factory, err := client.NewFactoryFromCredentialsDir(d.CredentialsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client factory from %q: %w", d.CredentialsDir, err)
+	}
+	return executor.NewExecutor(factory, config)
+}
+
+// CreateMachine creates a machine and returns its provider ID. ctx carries the gRPC call's deadline, which
+// propagates into Executor.CreateMachine so an MCM reconcile timeout actually aborts the underlying
+// waitForStatus poll instead of leaking a goroutine past the RPC's lifetime. secret is accepted for wire
+// compatibility but unused; see newExecutor.
+func (d *Driver) CreateMachine(ctx context.Context, config *api.MachineProviderConfig, _ *cloudprovider.Secret, machineName string, userData []byte) (string, error) {
+	ex, err := d.newExecutor(config)
+	if err != nil {
+		return "", err
+	}
+	return ex.CreateMachine(ctx, machineName, userData)
+}
+
+// DeleteMachine deletes a machine by name/providerID. secret is accepted for wire compatibility but unused;
+// see newExecutor.
+func (d *Driver) DeleteMachine(ctx context.Context, config *api.MachineProviderConfig, _ *cloudprovider.Secret, machineName, providerID string) error {
+	ex, err := d.newExecutor(config)
+	if err != nil {
+		return err
+	}
+	return ex.DeleteMachine(ctx, machineName, providerID)
+}
+
+// GetMachineStatus returns the provider ID of a machine if it exists and is healthy. secret is accepted for
+// wire compatibility but unused; see newExecutor.
+func (d *Driver) GetMachineStatus(ctx context.Context, config *api.MachineProviderConfig, _ *cloudprovider.Secret, machineName string) (string, error) {
+	ex, err := d.newExecutor(config)
+	if err != nil {
+		return "", err
+	}
+	return ex.GetMachineStatus(ctx, machineName)
+}
+
+// ListMachines lists all machines belonging to the MachineClass described by config. secret is accepted for
+// wire compatibility but unused; see newExecutor.
+func (d *Driver) ListMachines(ctx context.Context, config *api.MachineProviderConfig, _ *cloudprovider.Secret) (map[string]string, error) {
+	ex, err := d.newExecutor(config)
+	if err != nil {
+		return nil, err
+	}
+	return ex.ListMachines(ctx)
+}
+
+// GetVolumeIDs returns the Cinder volume IDs backing a list of PersistentVolumeSpecs attached to a machine
+// created by this driver, so MCM can wait for volume detachment before deleting the node.
+func (d *Driver) GetVolumeIDs(_ context.Context, volumeSpecs []api.CinderVolumeSource) ([]string, error) {
+	volumeIDs := make([]string, 0, len(volumeSpecs))
+	for _, spec := range volumeSpecs {
+		if spec.VolumeID != "" {
+			volumeIDs = append(volumeIDs, spec.VolumeID)
+		}
+	}
+	return volumeIDs, nil
+}
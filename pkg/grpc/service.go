@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serviceName is the fully-qualified gRPC service name this package serves, matching the MCM out-of-tree
+// driver convention of one "MachineDriver" service per provider.
+const serviceName = "openstack.MachineDriver"
+
+// RegisterMachineDriverServer registers d as the implementation of the MachineDriver gRPC service on server,
+// so that server.Serve actually dispatches CreateMachine/DeleteMachine/GetMachineStatus/ListMachines/
+// GetVolumeIDs calls to d instead of returning "unimplemented" for every RPC.
+func RegisterMachineDriverServer(server *grpc.Server, d *Driver) {
+	server.RegisterService(&machineDriverServiceDesc, d)
+}
+
+var machineDriverServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Driver)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "CreateMachine", Handler: createMachineHandler},
+		{MethodName: "DeleteMachine", Handler: deleteMachineHandler},
+		{MethodName: "GetMachineStatus", Handler: getMachineStatusHandler},
+		{MethodName: "ListMachines", Handler: listMachinesHandler},
+		{MethodName: "GetVolumeIDs", Handler: getVolumeIDsHandler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "openstack.proto",
+}
+
+func createMachineHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(createMachineRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	d := srv.(*Driver)
+	if interceptor == nil {
+		return createMachine(ctx, d, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/CreateMachine"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return createMachine(ctx, d, req.(*createMachineRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func createMachine(ctx context.Context, d *Driver, req *createMachineRequest) (*createMachineResponse, error) {
+	providerID, err := d.CreateMachine(ctx, req.MachineClass, req.Secret, req.MachineName, req.UserData)
+	if err != nil {
+		return nil, err
+	}
+	return &createMachineResponse{ProviderID: providerID}, nil
+}
+
+func deleteMachineHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(deleteMachineRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	d := srv.(*Driver)
+	if interceptor == nil {
+		return deleteMachine(ctx, d, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/DeleteMachine"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return deleteMachine(ctx, d, req.(*deleteMachineRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func deleteMachine(ctx context.Context, d *Driver, req *deleteMachineRequest) (*deleteMachineResponse, error) {
+	if err := d.DeleteMachine(ctx, req.MachineClass, req.Secret, req.MachineName, req.ProviderID); err != nil {
+		return nil, err
+	}
+	return &deleteMachineResponse{}, nil
+}
+
+func getMachineStatusHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(getMachineStatusRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	d := srv.(*Driver)
+	if interceptor == nil {
+		return getMachineStatus(ctx, d, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetMachineStatus"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return getMachineStatus(ctx, d, req.(*getMachineStatusRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func getMachineStatus(ctx context.Context, d *Driver, req *getMachineStatusRequest) (*getMachineStatusResponse, error) {
+	providerID, err := d.GetMachineStatus(ctx, req.MachineClass, req.Secret, req.MachineName)
+	if err != nil {
+		return nil, err
+	}
+	return &getMachineStatusResponse{ProviderID: providerID}, nil
+}
+
+func listMachinesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(listMachinesRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	d := srv.(*Driver)
+	if interceptor == nil {
+		return listMachines(ctx, d, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/ListMachines"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return listMachines(ctx, d, req.(*listMachinesRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func listMachines(ctx context.Context, d *Driver, req *listMachinesRequest) (*listMachinesResponse, error) {
+	machines, err := d.ListMachines(ctx, req.MachineClass, req.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return &listMachinesResponse{MachineList: machines}, nil
+}
+
+func getVolumeIDsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(getVolumeIDsRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	d := srv.(*Driver)
+	if interceptor == nil {
+		return getVolumeIDs(ctx, d, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetVolumeIDs"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return getVolumeIDs(ctx, d, req.(*getVolumeIDsRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
+func getVolumeIDs(ctx context.Context, d *Driver, req *getVolumeIDsRequest) (*getVolumeIDsResponse, error) {
+	volumeIDs, err := d.GetVolumeIDs(ctx, req.VolumeSpecs)
+	if err != nil {
+		return nil, err
+	}
+	return &getVolumeIDsResponse{VolumeIDs: volumeIDs}, nil
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSOptions configures the gRPC server's transport security. CAFile is optional; when set, the server
+// requires and verifies a client certificate signed by it (mTLS).
+type TLSOptions struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+// ServerOption builds the grpc.ServerOption implementing these TLS settings.
+func (o TLSOptions) ServerOption() (grpc.ServerOption, error) {
+	cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate/key: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if o.CAFile != "" {
+		caCert, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse client CA file %q", o.CAFile)
+		}
+
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		tlsConfig.ClientCAs = caPool
+	}
+
+	return grpc.Creds(credentials.NewTLS(tlsConfig)), nil
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package provider exposes the OpenStack machine power operations (stop, start, reboot) by delegating to an
+// executor.Executor supplied by the caller.
+package provider
+
+import (
+	"context"
+
+	"github.com/gardener/machine-controller-manager-provider-openstack/pkg/driver/executor"
+)
+
+// Provider wraps an Executor with the StopMachine/StartMachine/RebootMachine power operations; it does not
+// implement the rest of MCM's driver.Driver interface.
+type Provider struct {
+	Executor *executor.Executor
+}
+
+// NewProvider returns a Provider delegating to the given Executor.
+func NewProvider(ex *executor.Executor) *Provider {
+	return &Provider{Executor: ex}
+}
+
+// StopMachine stops the named machine. It is a plumb-through for MCM's power-management hooks (e.g.
+// cluster-autoscaler "hibernate" flows, maintenance windows) that do not yet exist upstream; MCM's
+// reconcile loop does not call this today.
+func (p *Provider) StopMachine(ctx context.Context, machineName, providerID string) error {
+	return p.Executor.StopMachine(ctx, machineName, providerID)
+}
+
+// StartMachine starts a previously stopped machine. See StopMachine for the same caveat about upstream MCM
+// hooks not existing yet.
+func (p *Provider) StartMachine(ctx context.Context, machineName, providerID string) error {
+	return p.Executor.StartMachine(ctx, machineName, providerID)
+}
+
+// RebootMachine reboots the named machine, hard or soft. See StopMachine for the same caveat about upstream
+// MCM hooks not existing yet.
+func (p *Provider) RebootMachine(ctx context.Context, machineName, providerID string, hard bool) error {
+	return p.Executor.RebootMachine(ctx, machineName, providerID, hard)
+}